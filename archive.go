@@ -0,0 +1,152 @@
+package mangodex
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Archiver packages a chapter's downloaded pages into an output format. A
+// ChapterDownloader writes each page's bytes into the writer Archiver.Open
+// returns as soon as that page has been fetched, rather than buffering a
+// whole chapter in memory.
+//
+// Implementations are not safe for concurrent use: callers driving Open from
+// multiple goroutines (eg a worker-pool downloader) must serialize their
+// calls to Open and to the writer it returns.
+type Archiver interface {
+	// Open is called once per page and returns a writer to stream that
+	// page's bytes into. page is the page's 0-based position in reading
+	// order; a concurrent downloader may call Open for pages in any order.
+	Open(page int, filename string) (io.WriteCloser, error)
+	// Close finalizes the archive, eg writing any trailing metadata.
+	Close() error
+}
+
+// PageWriter adapts an Archiver for use as a DownloadOpts.PageWriter.
+func PageWriter(a Archiver) func(page int, filename string) (io.WriteCloser, error) {
+	return a.Open
+}
+
+// DirArchiver writes each page as a plain file under Dir, named after its
+// original MD@Home filename.
+type DirArchiver struct {
+	Dir string
+}
+
+// NewDirArchiver returns a DirArchiver writing under dir.
+func NewDirArchiver(dir string) *DirArchiver {
+	return &DirArchiver{Dir: dir}
+}
+
+func (a *DirArchiver) Open(page int, filename string) (io.WriteCloser, error) {
+	return newFilePageWriter(a.Dir, filename)
+}
+
+func (a *DirArchiver) Close() error {
+	return nil
+}
+
+// ComicInfo is the subset of the ComicInfo.xml schema CBZArchiver populates
+// from chapter and manga metadata.
+type ComicInfo struct {
+	XMLName     xml.Name `xml:"ComicInfo"`
+	Title       string   `xml:"Title,omitempty"`
+	Series      string   `xml:"Series,omitempty"`
+	Volume      string   `xml:"Volume,omitempty"`
+	Number      string   `xml:"Number,omitempty"`
+	Summary     string   `xml:"Summary,omitempty"`
+	Year        int      `xml:"Year,omitempty"`
+	LanguageISO string   `xml:"LanguageISO,omitempty"`
+	Genre       string   `xml:"Genre,omitempty"`
+}
+
+// NewComicInfo builds a ComicInfo from a manga's attributes and a single
+// chapter's attributes.
+func NewComicInfo(manga *MangaAttributes, chapter *ChapterAttributes) ComicInfo {
+	ci := ComicInfo{
+		Series:      manga.Title.Property2,
+		Summary:     manga.Description.Property2,
+		Year:        manga.Year,
+		LanguageISO: chapter.TranslatedLanguage,
+		Number:      safeDeref(chapter.Chapter),
+		Volume:      safeDeref(chapter.Volume),
+		Title:       chapter.Title,
+	}
+	tags := make([]string, 0, len(manga.Tags))
+	for _, t := range manga.Tags {
+		if t != nil {
+			tags = append(tags, t.Property2)
+		}
+	}
+	ci.Genre = strings.Join(tags, ", ")
+	return ci
+}
+
+func safeDeref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// CBZArchiver packages a chapter's pages into a CBZ (zip) file, named
+// 001.jpg..NNN.jpg in reading order, plus a ComicInfo.xml populated from
+// manga and chapter metadata.
+type CBZArchiver struct {
+	zw        *zip.Writer
+	closer    io.Closer
+	comicInfo ComicInfo
+}
+
+// NewCBZArchiver returns a CBZArchiver writing to w, with ComicInfo.xml
+// populated from info. If w also implements io.Closer (eg an *os.File), it
+// is closed when the archiver is.
+func NewCBZArchiver(w io.Writer, info ComicInfo) *CBZArchiver {
+	a := &CBZArchiver{zw: zip.NewWriter(w), comicInfo: info}
+	if c, ok := w.(io.Closer); ok {
+		a.closer = c
+	}
+	return a
+}
+
+func (a *CBZArchiver) Open(page int, filename string) (io.WriteCloser, error) {
+	name := fmt.Sprintf("%03d%s", page+1, path.Ext(filename))
+	w, err := a.zw.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	return nopCloseWriter{w}, nil
+}
+
+func (a *CBZArchiver) Close() error {
+	w, err := a.zw.Create("ComicInfo.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	if err := enc.Encode(&a.comicInfo); err != nil {
+		return err
+	}
+	if err := a.zw.Close(); err != nil {
+		return err
+	}
+	if a.closer != nil {
+		return a.closer.Close()
+	}
+	return nil
+}
+
+// nopCloseWriter adapts an io.Writer (eg a zip entry, which has no per-entry
+// Close) to io.WriteCloser with a no-op Close.
+type nopCloseWriter struct {
+	io.Writer
+}
+
+func (nopCloseWriter) Close() error { return nil }
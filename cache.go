@@ -0,0 +1,243 @@
+package mangodex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CachePolicy controls how DexClient.EnableCache behaves: how long cached
+// responses stay fresh and how much disk space the cache may use.
+type CachePolicy struct {
+	// TTL maps a request path prefix (eg "manga/tag") to how long a cached
+	// response for that prefix stays valid. The longest matching prefix wins;
+	// paths matching no entry fall back to DefaultTTL.
+	TTL map[string]time.Duration
+	// DefaultTTL is used for paths that do not match any entry in TTL.
+	DefaultTTL time.Duration
+	// MaxSizeBytes caps the total size of the cache directory. Once a write
+	// would exceed it, the oldest entries (by fetched-at) are evicted first.
+	MaxSizeBytes int64
+}
+
+// DefaultCachePolicy is the CachePolicy EnableCache installs when the caller
+// has not set one of their own via SetCachePolicy.
+func DefaultCachePolicy() CachePolicy {
+	return CachePolicy{
+		TTL: map[string]time.Duration{
+			"manga/tag": 24 * time.Hour,
+		},
+		DefaultTTL:   5 * time.Minute,
+		MaxSizeBytes: 100 << 20, // 100MiB
+	}
+}
+
+// noCachePaths lists request path prefixes that must never be served from or
+// written to the on-disk cache: each call returns a different resource for
+// the same URL (eg GetRandomManga, or GetAtHomeServer, which a download's
+// failover re-requests for a new node), so keying by URL alone would pin
+// whatever the first call happened to return for DefaultTTL.
+var noCachePaths = []string{"manga/random", "at-home/server"}
+
+func cacheable(path string) bool {
+	for _, p := range noCachePaths {
+		if strings.HasPrefix(path, p) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cp *CachePolicy) ttlFor(path string) time.Duration {
+	ttl, bestLen := cp.DefaultTTL, -1
+	for prefix, t := range cp.TTL {
+		if strings.HasPrefix(path, prefix) && len(prefix) > bestLen {
+			ttl, bestLen = t, len(prefix)
+		}
+	}
+	return ttl
+}
+
+// cacheEntry is the on-disk representation of a cached GET response: a small
+// metadata header alongside the raw JSON body returned by the API.
+type cacheEntry struct {
+	Path      string          `json:"path"`
+	FetchedAt time.Time       `json:"fetchedAt"`
+	ETag      string          `json:"etag,omitempty"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// EnableCache turns on the on-disk response cache for read-only calls such as
+// ViewManga, MangaFeed, TagList and MangaReadMarkers. Entries are stored
+// under dir; if dir is empty, os.UserCacheDir()/mangodex is used.
+//
+// GetRandomManga and GetAtHomeServer are never cached (see noCachePaths):
+// they can return a different result on every call, so caching them by URL
+// would pin whatever the first call happened to return.
+func (dc *DexClient) EnableCache(dir string) error {
+	if dir == "" {
+		ucd, err := os.UserCacheDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(ucd, "mangodex")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	dc.cacheDir = dir
+	dc.cacheEnabled = true
+	if dc.cachePolicy == nil {
+		policy := DefaultCachePolicy()
+		dc.cachePolicy = &policy
+	}
+	return nil
+}
+
+// DisableCache turns off the response cache. Entries already on disk are left
+// alone so a later EnableCache call can reuse them.
+func (dc *DexClient) DisableCache() {
+	dc.cacheEnabled = false
+}
+
+// SetCachePolicy overrides the CachePolicy used by an already-enabled cache.
+func (dc *DexClient) SetCachePolicy(policy CachePolicy) {
+	dc.cachePolicy = &policy
+}
+
+// relPath returns rawURL's path, without the leading slash, for use as a
+// CachePolicy.TTL/cacheInvalidatePrefix match key.
+func relPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return strings.TrimPrefix(u.Path, "/")
+}
+
+// cacheKey derives the on-disk cache key for a GET request from its method
+// and full URL (including query string).
+func cacheKey(rawURL string) string {
+	h := sha256.Sum256([]byte("GET " + rawURL))
+	return hex.EncodeToString(h[:])
+}
+
+func (dc *DexClient) cachePath(rawURL string) string {
+	return filepath.Join(dc.cacheDir, cacheKey(rawURL)+".json")
+}
+
+// cacheLookup returns the cached body for rawURL, if present and not expired
+// under the current CachePolicy.
+func (dc *DexClient) cacheLookup(rawURL string) (json.RawMessage, bool) {
+	if !dc.cacheEnabled || !cacheable(relPath(rawURL)) {
+		return nil, false
+	}
+	raw, err := os.ReadFile(dc.cachePath(rawURL))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, false
+	}
+	if time.Since(entry.FetchedAt) > dc.cachePolicy.ttlFor(entry.Path) {
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+// cacheStore persists a successful GET response for rawURL.
+func (dc *DexClient) cacheStore(rawURL, etag string, body json.RawMessage) {
+	if !dc.cacheEnabled || !cacheable(relPath(rawURL)) {
+		return
+	}
+	raw, err := json.Marshal(&cacheEntry{Path: relPath(rawURL), FetchedAt: time.Now(), ETag: etag, Body: body})
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(dc.cachePath(rawURL), raw, 0o644); err != nil {
+		return
+	}
+	dc.enforceMaxSize()
+}
+
+// cacheInvalidatePrefix drops every cached entry whose original request path
+// starts with prefix, eg "manga/<id>" after a write to that resource.
+func (dc *DexClient) cacheInvalidatePrefix(prefix string) {
+	if !dc.cacheEnabled {
+		return
+	}
+	entries, err := os.ReadDir(dc.cacheDir)
+	if err != nil {
+		return
+	}
+	for _, fi := range entries {
+		name := filepath.Join(dc.cacheDir, fi.Name())
+		raw, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		var entry cacheEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+		if strings.HasPrefix(entry.Path, prefix) {
+			_ = os.Remove(name)
+		}
+	}
+}
+
+// enforceMaxSize evicts the oldest cache entries (by fetched-at) until the
+// cache directory fits within the configured CachePolicy.MaxSizeBytes.
+func (dc *DexClient) enforceMaxSize() {
+	if dc.cachePolicy.MaxSizeBytes <= 0 {
+		return
+	}
+	entries, err := os.ReadDir(dc.cacheDir)
+	if err != nil {
+		return
+	}
+
+	type fileInfo struct {
+		path      string
+		size      int64
+		fetchedAt time.Time
+	}
+	files := make([]fileInfo, 0, len(entries))
+	var total int64
+	for _, fi := range entries {
+		info, err := fi.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dc.cacheDir, fi.Name())
+		fetchedAt := info.ModTime()
+		if raw, err := os.ReadFile(path); err == nil {
+			var entry cacheEntry
+			if json.Unmarshal(raw, &entry) == nil {
+				fetchedAt = entry.FetchedAt
+			}
+		}
+		files = append(files, fileInfo{path: path, size: info.Size(), fetchedAt: fetchedAt})
+		total += info.Size()
+	}
+	if total <= dc.cachePolicy.MaxSizeBytes {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].fetchedAt.Before(files[j].fetchedAt) })
+	for _, f := range files {
+		if total <= dc.cachePolicy.MaxSizeBytes {
+			break
+		}
+		if err := os.Remove(f.path); err == nil {
+			total -= f.size
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package mangodex
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+)
+
+// ErrChapterNotFound is returned by ChapterByNumber and LatestChapter when no
+// chapter in a manga's feed matches the request.
+var ErrChapterNotFound = errors.New("mangodex: chapter not found")
+
+// ChaptersInRange returns the chapters of mangaID numbered between from and
+// to (inclusive) in lang, in ascending chapter order. Each chapter number
+// appears once: where several groups uploaded it, groupPriority picks the
+// copy as in ChapterByNumber. Chapters without a numeric chapter number (eg
+// oneshots or "EX1") are not included. ChaptersInRange is a
+// primitive for building downloaders on top of MangaFeed; see
+// DexClient.DownloadMangaChapters.
+func (dc *DexClient) ChaptersInRange(mangaID string, from, to float64, lang string, groupPriority ...string) ([]ChapterResponse, error) {
+	return dc.ChaptersInRangeContext(context.Background(), mangaID, from, to, lang, groupPriority...)
+}
+
+// ChaptersInRangeContext : ChaptersInRange with custom context.
+func (dc *DexClient) ChaptersInRangeContext(ctx context.Context, mangaID string, from, to float64, lang string, groupPriority ...string) ([]ChapterResponse, error) {
+	chapters, err := dc.feedInLang(ctx, mangaID, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := dedupeByChapterNumber(chapters, groupPriority)
+	out := make([]ChapterResponse, 0, len(byNumber))
+	for num, c := range byNumber {
+		if num < from || num > to {
+			continue
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		ni, _ := chapterNumber(&out[i])
+		nj, _ := chapterNumber(&out[j])
+		return ni < nj
+	})
+	return out, nil
+}
+
+// LatestChapter returns the highest-numbered chapter of mangaID in lang, as
+// ordered by the feed's order[chapter]=desc.
+func (dc *DexClient) LatestChapter(mangaID, lang string) (*ChapterResponse, error) {
+	return dc.LatestChapterContext(context.Background(), mangaID, lang)
+}
+
+// LatestChapterContext : LatestChapter with custom context.
+func (dc *DexClient) LatestChapterContext(ctx context.Context, mangaID, lang string) (*ChapterResponse, error) {
+	params := url.Values{
+		"limit":          []string{"1"},
+		"order[chapter]": []string{"desc"},
+	}
+	if lang != "" {
+		params["translatedLanguage[]"] = []string{lang}
+	}
+
+	l, err := dc.MangaFeedContext(ctx, mangaID, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(l.Results) == 0 {
+		return nil, ErrChapterNotFound
+	}
+	return &l.Results[0], nil
+}
+
+// ChapterByNumber returns the chapter of mangaID numbered number (eg "12.5"
+// or "EX1") in lang. When more than one group has uploaded that chapter
+// number, groupPriority (most-preferred group ID first) picks which copy is
+// returned; ties default to whichever the feed listed first.
+func (dc *DexClient) ChapterByNumber(mangaID, number, lang string, groupPriority ...string) (*ChapterResponse, error) {
+	return dc.ChapterByNumberContext(context.Background(), mangaID, number, lang, groupPriority...)
+}
+
+// ChapterByNumberContext : ChapterByNumber with custom context.
+func (dc *DexClient) ChapterByNumberContext(ctx context.Context, mangaID, number, lang string, groupPriority ...string) (*ChapterResponse, error) {
+	chapters, err := dc.feedInLang(ctx, mangaID, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []ChapterResponse
+	for _, c := range chapters {
+		if c.Data.Attributes.Chapter != nil && *c.Data.Attributes.Chapter == number {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, ErrChapterNotFound
+	}
+
+	best := matches[0]
+	bestRank := groupRank(best, groupPriority)
+	for _, c := range matches[1:] {
+		if rank := groupRank(c, groupPriority); rank < bestRank {
+			best, bestRank = c, rank
+		}
+	}
+	return &best, nil
+}
+
+// feedInLang walks a manga's whole feed (via MangaFeedAll) restricted to lang
+// and returns every chapter found.
+func (dc *DexClient) feedInLang(ctx context.Context, mangaID, lang string) ([]ChapterResponse, error) {
+	params := url.Values{}
+	if lang != "" {
+		params["translatedLanguage[]"] = []string{lang}
+	}
+
+	it := dc.MangaFeedAll(ctx, mangaID, params, DefaultIteratorOpts())
+	defer it.Close()
+
+	var chapters []ChapterResponse
+	for it.Next() {
+		chapters = append(chapters, *it.Chapter())
+	}
+	return chapters, it.Err()
+}
+
+// dedupeByChapterNumber collapses chapters sharing the same chapter number
+// (eg uploaded by multiple scanlation groups) to a single entry each,
+// preferring the highest-ranked group in groupPriority.
+func dedupeByChapterNumber(chapters []ChapterResponse, groupPriority []string) map[float64]ChapterResponse {
+	best := make(map[float64]ChapterResponse, len(chapters))
+	bestRank := make(map[float64]int, len(chapters))
+	for _, c := range chapters {
+		num, ok := chapterNumber(&c)
+		if !ok {
+			continue
+		}
+		rank := groupRank(c, groupPriority)
+		if _, seen := best[num]; !seen || rank < bestRank[num] {
+			best[num], bestRank[num] = c, rank
+		}
+	}
+	return best
+}
+
+// groupRank returns c's position in groupPriority (lower is preferred), or
+// len(groupPriority) if none of c's scanlation group relationships appear in
+// it.
+func groupRank(c ChapterResponse, groupPriority []string) int {
+	if len(groupPriority) == 0 {
+		return 0
+	}
+	best := len(groupPriority)
+	for _, rel := range c.Relationships {
+		if rel.Type != "scanlation_group" {
+			continue
+		}
+		for i, id := range groupPriority {
+			if rel.ID == id && i < best {
+				best = i
+			}
+		}
+	}
+	return best
+}
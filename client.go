@@ -0,0 +1,90 @@
+package mangodex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// BaseAPI is the root of the MangaDex REST API every request is resolved
+// against.
+const BaseAPI = "https://api.mangadex.org/"
+
+// DexClient is a MangaDex API client.
+type DexClient struct {
+	client *http.Client
+
+	cacheDir     string
+	cacheEnabled bool
+	cachePolicy  *CachePolicy
+}
+
+// NewDexClient returns a DexClient using http.DefaultClient. The on-disk
+// response cache is disabled until EnableCache is called.
+func NewDexClient() *DexClient {
+	return &DexClient{client: http.DefaultClient}
+}
+
+// responseOp resolves path against BaseAPI and dispatches method through
+// RequestAndDecode, decoding the JSON response body into out.
+func (dc *DexClient) responseOp(ctx context.Context, method, path string, body io.Reader, out interface{}) error {
+	u, err := url.Parse(BaseAPI)
+	if err != nil {
+		return err
+	}
+	u.Path = path
+
+	_, err = dc.RequestAndDecode(ctx, method, u.String(), body, out)
+	return err
+}
+
+// RequestAndDecode dispatches a request to rawURL and decodes its JSON
+// response body into out. For GET requests it consults the on-disk response
+// cache (see EnableCache) before dispatching, and populates the cache from a
+// successful response.
+func (dc *DexClient) RequestAndDecode(ctx context.Context, method, rawURL string, body io.Reader, out interface{}) (*http.Response, error) {
+	if method == http.MethodGet {
+		if raw, ok := dc.cacheLookup(rawURL); ok {
+			if out != nil {
+				if err := json.Unmarshal(raw, out); err != nil {
+					return nil, err
+				}
+			}
+			return nil, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := dc.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, fmt.Errorf("mangodex: %s %s: unexpected status %s", method, rawURL, resp.Status)
+	}
+
+	if out != nil && len(raw) > 0 {
+		if err := json.Unmarshal(raw, out); err != nil {
+			return resp, err
+		}
+	}
+
+	if method == http.MethodGet {
+		dc.cacheStore(rawURL, resp.Header.Get("ETag"), raw)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,335 @@
+package mangodex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AtHomeServerPath = "at-home/server/%s"
+	// ReportURL is the fixed MD@Home reporting endpoint; it lives on a
+	// different host to BaseAPI so it is not expressed as a %-style path.
+	ReportURL = "https://api.mangadex.network/report"
+)
+
+// Quality selects between the two page qualities MD@Home serves.
+type Quality string
+
+const (
+	QualityData      Quality = "data"
+	QualityDataSaver Quality = "data-saver"
+)
+
+// AtHomeServerResponse is the response of GET /at-home/server/{chapterId}.
+type AtHomeServerResponse struct {
+	Result  string        `json:"result"`
+	BaseURL string        `json:"baseUrl"`
+	Chapter AtHomeChapter `json:"chapter"`
+}
+
+// AtHomeChapter lists the page filenames served for a chapter by the
+// MD@Home node assigned in the enclosing AtHomeServerResponse.
+type AtHomeChapter struct {
+	Hash      string   `json:"hash"`
+	Data      []string `json:"data"`
+	DataSaver []string `json:"dataSaver"`
+}
+
+// GetAtHomeServer : Get the MD@Home server assigned to serve a chapter.
+// https://api.mangadex.org/docs.html#operation/get-at-home-server-chapterId
+func (dc *DexClient) GetAtHomeServer(chapterID string) (*AtHomeServerResponse, error) {
+	return dc.GetAtHomeServerContext(context.Background(), chapterID)
+}
+
+// GetAtHomeServerContext : GetAtHomeServer with custom context.
+func (dc *DexClient) GetAtHomeServerContext(ctx context.Context, chapterID string) (*AtHomeServerResponse, error) {
+	var ahs AtHomeServerResponse
+	err := dc.responseOp(ctx, http.MethodGet, fmt.Sprintf(AtHomeServerPath, chapterID), nil, &ahs)
+	return &ahs, err
+}
+
+// DownloadOpts configures ChapterDownloader.DownloadChapter.
+type DownloadOpts struct {
+	// Quality selects "data" (original) or "data-saver" pages. Defaults to
+	// QualityData.
+	Quality Quality
+	// Workers caps how many pages are fetched concurrently. Defaults to 4.
+	Workers int
+	// Dir, if set, writes each page to a file under Dir named after its
+	// original MD@Home filename.
+	Dir string
+	// PageWriter, if set, takes precedence over Dir: it is called once per
+	// page (with the page's 0-based reading-order index; pages fetched
+	// concurrently may arrive in any order) and the returned io.WriteCloser
+	// receives that page's bytes. This is the hook packaging output formats (eg a CBZ
+	// archiver) stream pages into instead of buffering a whole chapter.
+	PageWriter func(page int, filename string) (io.WriteCloser, error)
+}
+
+// PageResult reports the outcome of downloading a single chapter page.
+type PageResult struct {
+	Page     int
+	Filename string
+	Success  bool
+	Bytes    int
+	Err      error
+
+	// written is set once the page's bytes reached the page writer, so a
+	// failed write is not retried against a new node.
+	written bool
+}
+
+// DownloadResult is returned by DownloadChapter once every page has been
+// attempted.
+type DownloadResult struct {
+	ChapterID string
+	Hash      string
+	Pages     []PageResult
+}
+
+// ChapterDownloader streams chapter page images from a chapter's assigned
+// MD@Home node, reporting success/failure back to MangaDex as required by
+// their ToS.
+type ChapterDownloader struct {
+	dc     *DexClient
+	client *http.Client
+
+	// archMu serializes calls into DownloadOpts.PageWriter (eg an Archiver's
+	// Open and the writer it returns), since pages are fetched concurrently
+	// by a worker pool but Archiver implementations are not safe for
+	// concurrent use.
+	archMu sync.Mutex
+}
+
+// NewChapterDownloader returns a ChapterDownloader that uses dc to resolve
+// MD@Home servers.
+func (dc *DexClient) NewChapterDownloader() *ChapterDownloader {
+	return &ChapterDownloader{dc: dc, client: http.DefaultClient}
+}
+
+// DownloadChapter resolves chapterID's MD@Home node and downloads every page
+// according to opts, failing over to a freshly-assigned node if the current
+// one returns a 4xx/5xx for a page.
+func (dc *DexClient) DownloadChapter(ctx context.Context, chapterID string, opts DownloadOpts) (*DownloadResult, error) {
+	return dc.NewChapterDownloader().DownloadChapter(ctx, chapterID, opts)
+}
+
+// DownloadChapter is the method backing DexClient.DownloadChapter.
+func (cd *ChapterDownloader) DownloadChapter(ctx context.Context, chapterID string, opts DownloadOpts) (*DownloadResult, error) {
+	if opts.Quality == "" {
+		opts.Quality = QualityData
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = 4
+	}
+
+	ahs, err := cd.dc.GetAtHomeServerContext(ctx, chapterID)
+	if err != nil {
+		return nil, err
+	}
+
+	filenames := ahs.Chapter.Data
+	if opts.Quality == QualityDataSaver {
+		filenames = ahs.Chapter.DataSaver
+	}
+
+	result := &DownloadResult{ChapterID: chapterID, Hash: ahs.Chapter.Hash, Pages: make([]PageResult, len(filenames))}
+
+	var (
+		mu     sync.Mutex
+		curAHS = ahs
+		sem    = make(chan struct{}, opts.Workers)
+		wg     sync.WaitGroup
+	)
+	for i, name := range filenames {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(page int, filename string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			mu.Lock()
+			baseURL, quality, hash := curAHS.BaseURL, string(opts.Quality), curAHS.Chapter.Hash
+			mu.Unlock()
+
+			pr := cd.fetchPage(ctx, chapterID, page, filename, baseURL, quality, hash, opts)
+			if pr.Err != nil && !pr.written {
+				// Failover: re-request the at-home endpoint for a new node
+				// and retry this page once against it.
+				mu.Lock()
+				fresh, ferr := cd.dc.GetAtHomeServerContext(ctx, chapterID)
+				if ferr == nil {
+					curAHS = fresh
+				}
+				baseURL, hash = curAHS.BaseURL, curAHS.Chapter.Hash
+				mu.Unlock()
+				if ferr == nil {
+					pr = cd.fetchPage(ctx, chapterID, page, filename, baseURL, quality, hash, opts)
+				}
+			}
+
+			mu.Lock()
+			result.Pages[page] = pr
+			mu.Unlock()
+		}(i, name)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// fetchPage downloads a single page, writes it via opts, and reports the
+// outcome to the MangaDex report endpoint.
+func (cd *ChapterDownloader) fetchPage(ctx context.Context, chapterID string, page int, filename, baseURL, quality, hash string, opts DownloadOpts) PageResult {
+	pageURL := fmt.Sprintf("%s/%s/%s/%s", baseURL, quality, hash, filename)
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return PageResult{Page: page, Filename: filename, Err: err}
+	}
+
+	resp, err := cd.client.Do(req)
+	if err != nil {
+		return PageResult{Page: page, Filename: filename, Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("mangodex: fetch page %q: unexpected status %s", pageURL, resp.Status)
+		cd.report(ctx, pageURL, false, 0, time.Since(start), false)
+		return PageResult{Page: page, Filename: filename, Err: err}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	cached := strings.HasPrefix(resp.Header.Get("X-Cache"), "HIT")
+	if err != nil {
+		cd.report(ctx, pageURL, false, len(body), time.Since(start), cached)
+		return PageResult{Page: page, Filename: filename, Bytes: len(body), Err: err}
+	}
+
+	// Page bytes are fully read before touching the archiver so the worker
+	// pool's concurrency only covers the network fetch: an opts.PageWriter
+	// backed by an Archiver is not safe for concurrent use, so Open/Write/
+	// Close below must be serialized.
+	cd.archMu.Lock()
+	err = cd.writePage(page, filename, body, opts)
+	cd.archMu.Unlock()
+
+	cd.report(ctx, pageURL, err == nil, len(body), time.Since(start), cached)
+	if err != nil {
+		return PageResult{Page: page, Filename: filename, Bytes: len(body), Err: err, written: true}
+	}
+	return PageResult{Page: page, Filename: filename, Success: true, Bytes: len(body), written: true}
+}
+
+// writePage opens filename's writer via opts and writes body into it.
+// Callers must hold archMu: the writer may be backed by an Archiver, which is
+// not safe for concurrent use.
+func (cd *ChapterDownloader) writePage(page int, filename string, body []byte, opts DownloadOpts) error {
+	w, err := cd.pageWriter(page, filename, opts)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	_, err = w.Write(body)
+	return err
+}
+
+func (cd *ChapterDownloader) pageWriter(page int, filename string, opts DownloadOpts) (io.WriteCloser, error) {
+	if opts.PageWriter != nil {
+		return opts.PageWriter(page, filename)
+	}
+	if opts.Dir != "" {
+		return newFilePageWriter(opts.Dir, filename)
+	}
+	return nil, fmt.Errorf("mangodex: DownloadOpts must set Dir or PageWriter")
+}
+
+// reportPayload is the body required by the MD@Home reporting endpoint.
+type reportPayload struct {
+	URL      string `json:"url"`
+	Success  bool   `json:"success"`
+	Bytes    int    `json:"bytes"`
+	Duration int64  `json:"duration"`
+	Cached   bool   `json:"cached"`
+}
+
+// report posts the mandatory success/failure report for a single page back
+// to MangaDex. Failures to report are not surfaced: they must never abort an
+// otherwise successful download.
+func (cd *ChapterDownloader) report(ctx context.Context, pageURL string, success bool, nBytes int, d time.Duration, cached bool) {
+	payload := reportPayload{URL: pageURL, Success: success, Bytes: nBytes, Duration: d.Milliseconds(), Cached: cached}
+	rbytes, err := json.Marshal(&payload)
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ReportURL, bytes.NewBuffer(rbytes))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := cd.client.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// ChapterRange bounds a chapter download/listing by chapter number and
+// translated language. A zero Lang matches every language.
+type ChapterRange struct {
+	From, To float64
+	Lang     string
+}
+
+// DownloadMangaChapters downloads every chapter of mangaID in chapterRange,
+// composing with ChaptersInRange (itself built on the feed iterator) to walk
+// the manga's full feed instead of requiring the caller to paginate it
+// first.
+func (dc *DexClient) DownloadMangaChapters(ctx context.Context, mangaID string, chapterRange ChapterRange, opts DownloadOpts) ([]*DownloadResult, error) {
+	chapters, err := dc.ChaptersInRangeContext(ctx, mangaID, chapterRange.From, chapterRange.To, chapterRange.Lang)
+	if err != nil {
+		return nil, err
+	}
+
+	cd := dc.NewChapterDownloader()
+	results := make([]*DownloadResult, 0, len(chapters))
+	for _, c := range chapters {
+		r, err := cd.DownloadChapter(ctx, c.Data.ID, opts)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// chapterNumber parses a chapter's reported number, reporting ok=false for
+// chapters with no number (eg oneshots) or a non-numeric one (eg "EX1").
+func chapterNumber(c *ChapterResponse) (float64, bool) {
+	if c.Data.Attributes.Chapter == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(*c.Data.Attributes.Chapter, 64)
+	return n, err == nil
+}
+
+// newFilePageWriter opens filename under dir for writing, creating dir if it
+// does not already exist.
+func newFilePageWriter(dir, filename string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return os.Create(filepath.Join(dir, filename))
+}
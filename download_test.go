@@ -0,0 +1,114 @@
+package mangodex
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fixtureJPEG returns a tiny valid grayscale JPEG, standing in for a real
+// MD@Home page.
+func fixtureJPEG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, 4, 4))
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatalf("encode fixture jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// fetchPagesConcurrently drives cd.fetchPage from one goroutine per page, so
+// calls into a single archiver overlap as they do under DownloadChapter's
+// own worker pool.
+func fetchPagesConcurrently(t *testing.T, cd *ChapterDownloader, baseURL string, pages int, opts DownloadOpts) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for page := 0; page < pages; page++ {
+		wg.Add(1)
+		go func(page int) {
+			defer wg.Done()
+			filename := fmt.Sprintf("page-%d.jpg", page)
+			pr := cd.fetchPage(context.Background(), "chapter-id", page, filename, baseURL, "data", "hash", opts)
+			if !pr.Success {
+				t.Errorf("page %d: %v", page, pr.Err)
+			}
+		}(page)
+	}
+	wg.Wait()
+}
+
+// TestChapterDownloaderCBZArchiverConcurrent exercises the same concurrent
+// call pattern DownloadChapter uses against a CBZArchiver: this previously
+// raced inside archive/zip because nothing serialized Open across workers.
+func TestChapterDownloaderCBZArchiverConcurrent(t *testing.T) {
+	const pages = 8
+	page := fixtureJPEG(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(page)
+	}))
+	defer srv.Close()
+
+	cd := &ChapterDownloader{dc: NewDexClient(), client: srv.Client()}
+
+	var out bytes.Buffer
+	arc := NewCBZArchiver(&out, ComicInfo{Series: "Test Manga"})
+	opts := DownloadOpts{Workers: 4, PageWriter: PageWriter(arc)}
+
+	fetchPagesConcurrently(t, cd, srv.URL, pages, opts)
+
+	if err := arc.Close(); err != nil {
+		t.Fatalf("close archive: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("read cbz: %v", err)
+	}
+	if got, want := len(zr.File), pages+1; got != want { // +1 for ComicInfo.xml
+		t.Fatalf("got %d zip entries, want %d", got, want)
+	}
+}
+
+// TestChapterDownloaderPDFArchiverConcurrent exercises the same concurrent
+// call pattern DownloadChapter uses against a PDFArchiver: this previously
+// raced on PDFArchiver's offsets map/nextID/offset fields.
+func TestChapterDownloaderPDFArchiverConcurrent(t *testing.T) {
+	const pages = 8
+	page := fixtureJPEG(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(page)
+	}))
+	defer srv.Close()
+
+	cd := &ChapterDownloader{dc: NewDexClient(), client: srv.Client()}
+
+	var out bytes.Buffer
+	arc := NewPDFArchiver(&out)
+	opts := DownloadOpts{Workers: 4, PageWriter: PageWriter(arc)}
+
+	fetchPagesConcurrently(t, cd, srv.URL, pages, opts)
+
+	if err := arc.Close(); err != nil {
+		t.Fatalf("close archive: %v", err)
+	}
+
+	body := out.String()
+	if !strings.HasPrefix(body, "%PDF-1.4") {
+		t.Fatalf("output does not start with a PDF header")
+	}
+	if !strings.HasSuffix(strings.TrimRight(body, "\n"), "%%EOF") {
+		t.Fatalf("output does not end with %%%%EOF")
+	}
+	if got, want := strings.Count(body, "/Type /Page "), pages; got != want {
+		t.Fatalf("got %d page objects, want %d", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+//go:build ignore
+
+// Command gentags refreshes tags_gen.go from the live MangaDex tag list, so
+// tag IDs used with MangaListParams.IncludedTags/ExcludedTags can be
+// validated at build time instead of failing at request time on a typo.
+//
+// Run via `go generate` (see the directive in query.go).
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/ohenriquelopes/mangodex"
+)
+
+func main() {
+	dc := mangodex.NewDexClient()
+	tags, err := dc.TagList()
+	if err != nil {
+		log.Fatalf("gentags: fetch tag list: %v", err)
+	}
+
+	if len(tags.Data) == 0 {
+		log.Fatalf("gentags: tag list came back empty")
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by `go generate`; DO NOT EDIT.\n\n")
+	b.WriteString("package mangodex\n\n")
+	b.WriteString("// Tag<Name> constants are MangaDex tag UUIDs, for use with\n")
+	b.WriteString("// MangaListParams.IncludedTags/ExcludedTags. Refresh with `go generate`.\n")
+	b.WriteString("const (\n")
+	for _, t := range tags.Data {
+		fmt.Fprintf(&b, "\t%s = %q\n", constName(t.Attributes.Name.Property2), t.ID)
+	}
+	b.WriteString(")\n")
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		log.Fatalf("gentags: format output: %v", err)
+	}
+	if err := os.WriteFile("tags_gen.go", src, 0o644); err != nil {
+		log.Fatalf("gentags: write tags_gen.go: %v", err)
+	}
+}
+
+// constName turns a tag name like "Slice of Life" into a Go identifier like
+// "TagSliceOfLife".
+func constName(tagName string) string {
+	var b strings.Builder
+	b.WriteString("Tag")
+	upperNext := true
+	for _, r := range tagName {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upperNext {
+				b.WriteRune(unicode.ToUpper(r))
+				upperNext = false
+			} else {
+				b.WriteRune(r)
+			}
+		default:
+			upperNext = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,223 @@
+package mangodex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// defaultIteratorPageSize is the page size FeedIterator requests when the
+// caller's params does not already set a limit.
+const defaultIteratorPageSize = 100
+
+// IteratorOpts configures the prefetch behaviour of a FeedIterator.
+type IteratorOpts struct {
+	// Prefetch, when true, fetches the next page in the background while the
+	// current page is still being consumed by the caller. When false, pages
+	// are fetched synchronously as Next needs them.
+	Prefetch bool
+	// MaxBackoff caps how long the iterator waits after a failed fetch (eg a
+	// 429) before retrying. Backoff starts at one second and doubles.
+	MaxBackoff time.Duration
+}
+
+// DefaultIteratorOpts is used by MangaFeedAll when the zero value of
+// IteratorOpts is passed.
+func DefaultIteratorOpts() IteratorOpts {
+	return IteratorOpts{Prefetch: true, MaxBackoff: 30 * time.Second}
+}
+
+// FeedIterator lazily walks every chapter of a manga feed, fetching
+// subsequent pages as needed instead of requiring the caller to manage
+// limit/offset themselves. Obtain one via DexClient.MangaFeedAll.
+type FeedIterator struct {
+	dc     *DexClient
+	ctx    context.Context
+	id     string
+	params url.Values
+	opts   IteratorOpts
+
+	page    []ChapterResponse
+	pageIdx int
+	offset  int
+	total   int
+	started bool
+	closed  bool
+	err     error
+	cur     *ChapterResponse
+
+	// pending is non-nil only while opts.Prefetch is true and a background
+	// fetch for the next page is in flight. total/offset/page are only ever
+	// mutated from the consumer goroutine, in awaitPage, once that fetch's
+	// result has been received over this channel.
+	pending chan pageResult
+}
+
+type pageResult struct {
+	page  []ChapterResponse
+	total int
+	err   error
+}
+
+// MangaFeedAll returns a FeedIterator over every chapter in a manga's feed
+// matching params; offset in params is managed internally and will be
+// overwritten as the iterator pages through results, and limit sets the page
+// size (defaultIteratorPageSize if params does not set it).
+func (dc *DexClient) MangaFeedAll(ctx context.Context, id string, params url.Values, opts IteratorOpts) *FeedIterator {
+	if opts == (IteratorOpts{}) {
+		opts = DefaultIteratorOpts()
+	}
+	if params == nil {
+		params = url.Values{}
+	}
+	if params.Get("limit") == "" {
+		params.Set("limit", strconv.Itoa(defaultIteratorPageSize))
+	}
+	return &FeedIterator{dc: dc, ctx: ctx, id: id, params: params, opts: opts}
+}
+
+// Next advances the iterator to the following chapter, fetching the next
+// page of the feed on demand. It returns false once the feed is exhausted or
+// an error has occurred; call Err to distinguish the two.
+func (it *FeedIterator) Next() bool {
+	if it.closed || it.err != nil {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+		if it.opts.Prefetch && it.offset < it.total {
+			it.startPrefetch()
+		}
+	}
+
+	if it.pageIdx >= len(it.page) {
+		if it.offset >= it.total || len(it.page) == 0 {
+			return false
+		}
+
+		var err error
+		if it.opts.Prefetch {
+			err = it.awaitPage()
+		} else {
+			err = it.fetchPage()
+		}
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+
+	c := it.page[it.pageIdx]
+	it.cur = &c
+	it.pageIdx++
+	if it.pageIdx >= len(it.page) && it.opts.Prefetch && it.offset < it.total {
+		it.startPrefetch()
+	}
+	return true
+}
+
+// Chapter returns the chapter the most recent call to Next advanced to.
+func (it *FeedIterator) Chapter() *ChapterResponse {
+	return it.cur
+}
+
+// Err returns the first error encountered while paging through the feed, if
+// any. It should be checked once Next returns false.
+func (it *FeedIterator) Err() error {
+	return it.err
+}
+
+// Close releases the iterator; it is safe to call multiple times and makes
+// any in-flight prefetch result discarded once it arrives.
+func (it *FeedIterator) Close() error {
+	it.closed = true
+	return nil
+}
+
+// fetchPage synchronously fetches the page at the iterator's current offset,
+// backing off and retrying on error up to IteratorOpts.MaxBackoff, and
+// updates the iterator's page/offset/total from it.
+func (it *FeedIterator) fetchPage() error {
+	l, err := it.dc.fetchFeedPage(it.ctx, it.id, it.pageParams(it.offset), it.opts.MaxBackoff)
+	if err != nil {
+		return err
+	}
+	it.page, it.pageIdx, it.total = l.Results, 0, l.Total
+	it.offset += len(l.Results)
+	return nil
+}
+
+// startPrefetch kicks off a background fetch of the next page so it is ready
+// by the time the caller finishes consuming the current one. The offset to
+// fetch is captured up front so the goroutine never reads iterator state
+// that the consumer goroutine might concurrently mutate.
+func (it *FeedIterator) startPrefetch() {
+	pending := make(chan pageResult, 1)
+	it.pending = pending
+	go func(params url.Values, maxBackoff time.Duration) {
+		l, err := it.dc.fetchFeedPage(it.ctx, it.id, params, maxBackoff)
+		if err != nil {
+			pending <- pageResult{err: err}
+			return
+		}
+		pending <- pageResult{page: l.Results, total: l.Total}
+	}(it.pageParams(it.offset), it.opts.MaxBackoff)
+}
+
+// awaitPage blocks for the in-flight prefetch started by startPrefetch and
+// applies its result; it is the sole writer of offset/total once prefetching
+// is in use.
+func (it *FeedIterator) awaitPage() error {
+	res := <-it.pending
+	it.pending = nil
+	if res.err != nil {
+		return res.err
+	}
+	it.page, it.pageIdx, it.total = res.page, 0, res.total
+	it.offset += len(res.page)
+	return nil
+}
+
+// pageParams clones the iterator's base params with offset substituted in.
+func (it *FeedIterator) pageParams(offset int) url.Values {
+	params := cloneValues(it.params)
+	params.Set("offset", strconv.Itoa(offset))
+	return params
+}
+
+// fetchFeedPage fetches one feed page, retrying with exponential backoff
+// (capped at maxBackoff, eg after a 429) before giving up.
+func (dc *DexClient) fetchFeedPage(ctx context.Context, id string, params url.Values, maxBackoff time.Duration) (*ChapterList, error) {
+	backoff := time.Second
+	for {
+		l, err := dc.MangaFeedContext(ctx, id, params)
+		if err == nil {
+			return l, nil
+		}
+		if maxBackoff <= 0 || backoff > maxBackoff {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func cloneValues(v url.Values) url.Values {
+	out := make(url.Values, len(v))
+	for k, vs := range v {
+		out[k] = append([]string(nil), vs...)
+	}
+	return out
+}
@@ -85,10 +85,15 @@ func (rmr *ChapterReadMarkersResponse) GetResult() string {
 	return rmr.Result
 }
 
+// TagResponse is the response of GET /manga/tag: it is a collection response
+// (every tag MangaDex knows about comes back in one call, unpaginated), not
+// a single-resource response, despite the singular name.
 type TagResponse struct {
-	Result        string         `json:"result"`
-	Data          Tag            `json:"data"`
-	Relationships []Relationship `json:"relationships"`
+	Result string `json:"result"`
+	Data   []Tag  `json:"data"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+	Total  int    `json:"total"`
 }
 
 func (tg *TagResponse) GetResult() string {
@@ -151,6 +156,9 @@ func (dc *DexClient) UpdateManga(id string, upManga io.Reader) (*MangaResponse,
 func (dc *DexClient) UpdateMangaContext(ctx context.Context, id string, upManga io.Reader) (*MangaResponse, error) {
 	var mr MangaResponse
 	err := dc.responseOp(ctx, http.MethodPut, fmt.Sprintf(UpdateMangaPath, id), upManga, &mr)
+	if err == nil {
+		dc.cacheInvalidatePrefix(fmt.Sprintf("manga/%s", id))
+	}
 	return &mr, err
 }
 
@@ -162,7 +170,11 @@ func (dc *DexClient) DeleteManga(id string) error {
 
 // DeleteMangaContext : DeleteManga with custom context.
 func (dc *DexClient) DeleteMangaContext(ctx context.Context, id string) error {
-	return dc.responseOp(ctx, http.MethodDelete, fmt.Sprintf(DeleteMangaPath, id), nil, nil)
+	err := dc.responseOp(ctx, http.MethodDelete, fmt.Sprintf(DeleteMangaPath, id), nil, nil)
+	if err == nil {
+		dc.cacheInvalidatePrefix(fmt.Sprintf("manga/%s", id))
+	}
+	return err
 }
 
 // UnfollowManga : Unfollow a Manga by ID.
@@ -173,7 +185,11 @@ func (dc *DexClient) UnfollowManga(id string) error {
 
 // UnfollowMangaContext : UnfollowManga with custom context.
 func (dc *DexClient) UnfollowMangaContext(ctx context.Context, id string) error {
-	return dc.responseOp(ctx, http.MethodDelete, fmt.Sprintf(UnfollowMangaPath, id), nil, nil)
+	err := dc.responseOp(ctx, http.MethodDelete, fmt.Sprintf(UnfollowMangaPath, id), nil, nil)
+	if err == nil {
+		dc.cacheInvalidatePrefix(fmt.Sprintf("manga/%s", id))
+	}
+	return err
 }
 
 // FollowManga : Follow a Manga by ID.
@@ -184,7 +200,11 @@ func (dc *DexClient) FollowManga(id string) error {
 
 // FollowMangaContext : FollowManga with custom context.
 func (dc *DexClient) FollowMangaContext(ctx context.Context, id string) error {
-	return dc.responseOp(ctx, http.MethodPost, fmt.Sprintf(FollowMangaPath, id), nil, nil)
+	err := dc.responseOp(ctx, http.MethodPost, fmt.Sprintf(FollowMangaPath, id), nil, nil)
+	if err == nil {
+		dc.cacheInvalidatePrefix(fmt.Sprintf("manga/%s", id))
+	}
+	return err
 }
 
 // MangaFeed : Get Manga feed by ID.
@@ -0,0 +1,174 @@
+package mangodex
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"sort"
+)
+
+// PDFArchiver packages a chapter's pages into a PDF, one page per image,
+// sized to that image's own pixel dimensions so aspect ratio is always
+// preserved exactly. Pages appear in order of their page index, whatever
+// order Open is called in.
+//
+// Only the current page's bytes are buffered (to measure its dimensions and
+// the length of its embedded stream); nothing from earlier pages is kept in
+// memory.
+type PDFArchiver struct {
+	w       io.Writer
+	closer  io.Closer
+	offset  int64
+	offsets map[int]int64
+	nextID  int
+	pagesID int
+	rootID  int
+	pageIDs map[int]int // page index -> page object ID
+	err     error
+}
+
+// NewPDFArchiver returns a PDFArchiver writing to w. If w also implements
+// io.Closer (eg an *os.File), it is closed when the archiver is.
+func NewPDFArchiver(w io.Writer) *PDFArchiver {
+	a := &PDFArchiver{w: w, offsets: map[int]int64{}, pageIDs: map[int]int{}, nextID: 1}
+	if c, ok := w.(io.Closer); ok {
+		a.closer = c
+	}
+	a.pagesID = a.allocID()
+	a.rootID = a.allocID()
+	a.writeRaw("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	return a
+}
+
+func (a *PDFArchiver) allocID() int {
+	id := a.nextID
+	a.nextID++
+	return id
+}
+
+func (a *PDFArchiver) writeRaw(s string) {
+	if a.err != nil {
+		return
+	}
+	n, err := io.WriteString(a.w, s)
+	a.offset += int64(n)
+	a.err = err
+}
+
+func (a *PDFArchiver) writeObject(id int, body string) {
+	a.offsets[id] = a.offset
+	a.writeRaw(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", id, body))
+}
+
+// Open returns a writer for page's image bytes; the page is emitted as a PDF
+// object as soon as the returned writer is closed.
+func (a *PDFArchiver) Open(page int, filename string) (io.WriteCloser, error) {
+	return &pdfPageWriter{a: a, page: page}, nil
+}
+
+type pdfPageWriter struct {
+	a    *PDFArchiver
+	page int
+	buf  bytes.Buffer
+}
+
+func (pw *pdfPageWriter) Write(p []byte) (int, error) {
+	return pw.buf.Write(p)
+}
+
+func (pw *pdfPageWriter) Close() error {
+	return pw.a.addPage(pw.page, pw.buf.Bytes())
+}
+
+// addPage embeds one page's JPEG bytes (re-encoding to JPEG first if
+// necessary) as an Image XObject on its own page, sized to the image's pixel
+// dimensions.
+func (a *PDFArchiver) addPage(page int, raw []byte) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("mangodex: decode page %d image: %w", page, err)
+	}
+	jpegBytes := raw
+	isGray := cfg.ColorModel == color.GrayModel
+	if format != "jpeg" {
+		img, _, err := image.Decode(bytes.NewReader(raw))
+		if err != nil {
+			return fmt.Errorf("mangodex: decode page %d image: %w", page, err)
+		}
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+			return fmt.Errorf("mangodex: re-encode page %d as jpeg: %w", page, err)
+		}
+		jpegBytes = buf.Bytes()
+		isGray = img.ColorModel() == color.GrayModel
+	}
+	colorSpace := "DeviceRGB"
+	if isGray {
+		colorSpace = "DeviceGray"
+	}
+
+	imgID := a.allocID()
+	a.writeObject(imgID, fmt.Sprintf(
+		"<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>\nstream\n%s\nendstream",
+		cfg.Width, cfg.Height, colorSpace, len(jpegBytes), jpegBytes))
+
+	content := fmt.Sprintf("q %d 0 0 %d 0 0 cm /Im0 Do Q", cfg.Width, cfg.Height)
+	contentID := a.allocID()
+	a.writeObject(contentID, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+
+	pageID := a.allocID()
+	a.writeObject(pageID, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>",
+		a.pagesID, cfg.Width, cfg.Height, imgID, contentID))
+
+	a.pageIDs[page] = pageID
+	return a.err
+}
+
+// Close finalizes the PDF: the page tree, catalog, cross-reference table and
+// trailer.
+func (a *PDFArchiver) Close() error {
+	if a.err != nil {
+		return a.err
+	}
+
+	pages := make([]int, 0, len(a.pageIDs))
+	for page := range a.pageIDs {
+		pages = append(pages, page)
+	}
+	sort.Ints(pages)
+	kids := ""
+	for _, page := range pages {
+		kids += fmt.Sprintf("%d 0 R ", a.pageIDs[page])
+	}
+	a.offsets[a.pagesID] = a.offset
+	a.writeRaw(fmt.Sprintf("%d 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", a.pagesID, kids, len(a.pageIDs)))
+	a.offsets[a.rootID] = a.offset
+	a.writeRaw(fmt.Sprintf("%d 0 obj\n<< /Type /Catalog /Pages %d 0 R >>\nendobj\n", a.rootID, a.pagesID))
+
+	xrefOffset := a.offset
+	total := a.nextID
+	a.writeRaw(fmt.Sprintf("xref\n0 %d\n", total))
+	a.writeRaw("0000000000 65535 f \n")
+	for id := 1; id < total; id++ {
+		a.writeRaw(fmt.Sprintf("%010d 00000 n \n", a.offsets[id]))
+	}
+	a.writeRaw(fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF", total, a.rootID, xrefOffset))
+
+	if a.err != nil {
+		return a.err
+	}
+	if a.closer != nil {
+		return a.closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package mangodex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+//go:generate go run gentags.go
+
+// TagMode selects how IncludedTags/ExcludedTags are combined in a
+// MangaListParams query: AND requires every tag to match, OR requires any
+// one of them to.
+type TagMode string
+
+const (
+	TagModeAnd TagMode = "AND"
+	TagModeOr  TagMode = "OR"
+)
+
+// ContentRating filters manga or chapters by content rating.
+type ContentRating string
+
+const (
+	ContentRatingSafe         ContentRating = "safe"
+	ContentRatingSuggestive   ContentRating = "suggestive"
+	ContentRatingErotica      ContentRating = "erotica"
+	ContentRatingPornographic ContentRating = "pornographic"
+)
+
+// PublicationDemographic filters manga by target demographic.
+type PublicationDemographic string
+
+const (
+	DemographicShounen PublicationDemographic = "shounen"
+	DemographicShoujo  PublicationDemographic = "shoujo"
+	DemographicJosei   PublicationDemographic = "josei"
+	DemographicSeinen  PublicationDemographic = "seinen"
+	DemographicNone    PublicationDemographic = "none"
+)
+
+// MangaStatus is a manga's publication status, as used by the MangaListParams
+// status[] filter. This is distinct from ReadStatus, which tracks a manga's
+// position in a user's personal reading list.
+type MangaStatus string
+
+const (
+	MangaStatusOngoing   MangaStatus = "ongoing"
+	MangaStatusCompleted MangaStatus = "completed"
+	MangaStatusHiatus    MangaStatus = "hiatus"
+	MangaStatusCancelled MangaStatus = "cancelled"
+)
+
+// OrderDirection is the sort direction for a MangaListParams/MangaFeedParams
+// Order entry.
+type OrderDirection string
+
+const (
+	OrderAsc  OrderDirection = "asc"
+	OrderDesc OrderDirection = "desc"
+)
+
+// MangaListParams is a typed alternative to building url.Values by hand for
+// the manga search filters MangaDex supports. Zero-valued fields are omitted
+// from Encode's output.
+type MangaListParams struct {
+	Title                  string
+	Authors                []string
+	Artists                []string
+	Year                   int
+	IncludedTags           []string
+	IncludedTagsMode       TagMode
+	ExcludedTags           []string
+	ExcludedTagsMode       TagMode
+	Status                 []MangaStatus
+	OriginalLanguage       []string
+	ContentRating          []ContentRating
+	PublicationDemographic []PublicationDemographic
+	Includes               []string
+	Order                  map[string]OrderDirection
+	Limit, Offset          int
+}
+
+// Encode renders params as url.Values, using the array-bracket syntax
+// MangaDex requires for repeated filters (eg includedTags[]=...).
+func (params MangaListParams) Encode() url.Values {
+	v := url.Values{}
+	setString(v, "title", params.Title)
+	setStrings(v, "authors[]", params.Authors)
+	setStrings(v, "artists[]", params.Artists)
+	setInt(v, "year", params.Year)
+	setStrings(v, "includedTags[]", params.IncludedTags)
+	setString(v, "includedTagsMode", string(params.IncludedTagsMode))
+	setStrings(v, "excludedTags[]", params.ExcludedTags)
+	setString(v, "excludedTagsMode", string(params.ExcludedTagsMode))
+	setStrings(v, "status[]", mangaStatusStrings(params.Status))
+	setStrings(v, "originalLanguage[]", params.OriginalLanguage)
+	setStrings(v, "contentRating[]", contentRatingStrings(params.ContentRating))
+	setStrings(v, "publicationDemographic[]", demographicStrings(params.PublicationDemographic))
+	setStrings(v, "includes[]", params.Includes)
+	setOrder(v, params.Order)
+	setInt(v, "limit", params.Limit)
+	setInt(v, "offset", params.Offset)
+	return v
+}
+
+// MangaListWithParams : MangaList using a typed MangaListParams instead of
+// raw url.Values.
+func (dc *DexClient) MangaListWithParams(params MangaListParams) (*MangaList, error) {
+	return dc.MangaListWithParamsContext(context.Background(), params)
+}
+
+// MangaListWithParamsContext : MangaListWithParams with custom context.
+func (dc *DexClient) MangaListWithParamsContext(ctx context.Context, params MangaListParams) (*MangaList, error) {
+	return dc.MangaListContext(ctx, params.Encode())
+}
+
+// MangaFeedParams is a typed alternative to building url.Values by hand for
+// MangaFeed's filters.
+type MangaFeedParams struct {
+	TranslatedLanguage []string
+	OriginalLanguage   []string
+	ExcludedGroups     []string
+	ExcludedUploaders  []string
+	ContentRating      []ContentRating
+	Includes           []string
+	Order              map[string]OrderDirection
+	Limit, Offset      int
+}
+
+// Encode renders params as url.Values, using the array-bracket syntax
+// MangaDex requires for repeated filters.
+func (params MangaFeedParams) Encode() url.Values {
+	v := url.Values{}
+	setStrings(v, "translatedLanguage[]", params.TranslatedLanguage)
+	setStrings(v, "originalLanguage[]", params.OriginalLanguage)
+	setStrings(v, "excludedGroups[]", params.ExcludedGroups)
+	setStrings(v, "excludedUploaders[]", params.ExcludedUploaders)
+	setStrings(v, "contentRating[]", contentRatingStrings(params.ContentRating))
+	setStrings(v, "includes[]", params.Includes)
+	setOrder(v, params.Order)
+	setInt(v, "limit", params.Limit)
+	setInt(v, "offset", params.Offset)
+	return v
+}
+
+// MangaFeedWithParams : MangaFeed using a typed MangaFeedParams instead of
+// raw url.Values.
+func (dc *DexClient) MangaFeedWithParams(id string, params MangaFeedParams) (*ChapterList, error) {
+	return dc.MangaFeedWithParamsContext(context.Background(), id, params)
+}
+
+// MangaFeedWithParamsContext : MangaFeedWithParams with custom context.
+func (dc *DexClient) MangaFeedWithParamsContext(ctx context.Context, id string, params MangaFeedParams) (*ChapterList, error) {
+	return dc.MangaFeedContext(ctx, id, params.Encode())
+}
+
+func setString(v url.Values, key, val string) {
+	if val != "" {
+		v.Set(key, val)
+	}
+}
+
+func setInt(v url.Values, key string, val int) {
+	if val != 0 {
+		v.Set(key, strconv.Itoa(val))
+	}
+}
+
+func setStrings(v url.Values, key string, vals []string) {
+	for _, val := range vals {
+		v.Add(key, val)
+	}
+}
+
+func setOrder(v url.Values, order map[string]OrderDirection) {
+	for field, dir := range order {
+		v.Set(fmt.Sprintf("order[%s]", field), string(dir))
+	}
+}
+
+func mangaStatusStrings(vals []MangaStatus) []string {
+	out := make([]string, len(vals))
+	for i, val := range vals {
+		out[i] = string(val)
+	}
+	return out
+}
+
+func contentRatingStrings(vals []ContentRating) []string {
+	out := make([]string, len(vals))
+	for i, val := range vals {
+		out[i] = string(val)
+	}
+	return out
+}
+
+func demographicStrings(vals []PublicationDemographic) []string {
+	out := make([]string, len(vals))
+	for i, val := range vals {
+		out[i] = string(val)
+	}
+	return out
+}